@@ -2,17 +2,26 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"syscall"
 	"time" // Used for safety pauses during setup
 
-	"github.com/atotto/clipboard"
 	"github.com/gen2brain/dlgs"
 	"github.com/sqweek/dialog"
 	"golang.org/x/sys/windows/registry"
+
+	"github.com/supportproxy/dgGit/pkg/clipboardx"
+	"github.com/supportproxy/dgGit/pkg/gitops"
+	"github.com/supportproxy/dgGit/pkg/logging"
+	"github.com/supportproxy/dgGit/pkg/namer"
+	"github.com/supportproxy/dgGit/pkg/reviewui"
 )
 
 // --- CONSTANTS ---
@@ -29,34 +38,134 @@ type Config struct {
 	ShowSuccess   bool
 	AutoSave      bool
 	GitAutoCommit bool
+
+	// Git backend (see pkg/gitops). GitBackend selects "shell" (default,
+	// shells out to the git binary) or "gogit" (in-process via go-git).
+	GitBackend            string
+	GitAutoInit           bool
+	GitAuthorName         string
+	GitAuthorEmail        string
+	GitCommitTemplate     string
+	GitRemote             string
+	GitBranch             string
+	GitBatchCommits       int
+	GitBatchWindowSeconds int
+
+	// Clipboard backend (see pkg/clipboardx). ClipboardBackend selects
+	// "native" (default), "shell" (ClipboardReadCmd/ClipboardWriteCmd), or
+	// "osc52" (clear-clipboard writes via a terminal escape sequence).
+	ClipboardBackend  string
+	ClipboardReadCmd  string
+	ClipboardWriteCmd string
+
+	// Namer (see pkg/namer). User-defined fallback parsers, keyed by the
+	// language name used in "Namer.<lang>.Pattern" / "Namer.<lang>.Extension".
+	NamerRules map[string]*NamerRule
+
+	// LogLevel for pkg/logging: "debug", "info", "warn" or "error".
+	// Overridden to "debug" for the duration of a run when --debug is passed.
+	LogLevel string
+}
+
+// NamerRule is a user-configured namer.RegexParser read from dggit.cfg.
+type NamerRule struct {
+	Pattern   string
+	Extension string
 }
 
 // -- MY NOTES --
 // go build -ldflags -H=windowsgui -o dgGit.exe
 
+// main dispatches to a subcommand ("install", "uninstall", "setup", "save",
+// "ignore") when the first remaining argument names one, mirroring git-lfs's
+// subcommand style. Registry entries created before the subcommands existed
+// invoke the exe with a bare folder path and no subcommand, so that shape
+// still falls through to save. "--debug", in any position, raises the log
+// level to debug and mirrors log output to stderr; "--review" opens the
+// review TUI (see pkg/reviewui) before saving.
 func main() {
+	args, debug := extractFlag(os.Args[1:], "--debug")
+	args, review := extractFlag(args, "--review")
+
+	initialLevel := "info"
+	if debug {
+		initialLevel = "debug"
+	}
+	_ = logging.Init(initialLevel, AppName, debug)
+
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "install":
+			runInstallCommand(args[1:])
+			return
+		case "uninstall":
+			runUninstallCommand(args[1:])
+			return
+		case "setup":
+			runSetupCommand()
+			return
+		case "save":
+			runSave(args[1:], debug, review)
+			return
+		case "ignore":
+			runIgnoreCommand(args[1:])
+			return
+		}
+	}
+	runSave(args, debug, review)
+}
+
+// extractFlag removes flag from args wherever it appears, since a
+// context-menu command line can put it before or after the folder path.
+func extractFlag(args []string, flag string) (remaining []string, found bool) {
+	for _, a := range args {
+		if a == flag {
+			found = true
+			continue
+		}
+		remaining = append(remaining, a)
+	}
+	return remaining, found
+}
+
+// runSave is dgGit's default action: read the clipboard, derive a filename,
+// save it under saveDir, and optionally git-commit it. When review is set,
+// it shows the reviewui dialog first so the user can edit the filename,
+// folder and git options before anything is written.
+func runSave(args []string, debug, review bool) {
 	// 1. Load Config
 	cfg, cancelled, isFirstRun := loadConfig()
 	if cancelled {
+		logging.L().Info("setup wizard cancelled")
 		return
 	}
 
-	// 2. Registry Maintenance
-	if err := updateRegistry(); err != nil {
-		dlgs.Error(AppName, fmt.Sprintf("Setup Error:\nFailed to update registry settings.\n%s", err))
-		return
+	level := cfg.LogLevel
+	if debug {
+		level = "debug"
 	}
+	_ = logging.Init(level, AppName, debug)
+	logging.L().WithField("first_run", isFirstRun).Info("config loaded")
 
 	if isFirstRun {
+		// Registry maintenance now only happens via the "install"
+		// subcommand, except for this one-time setup-wizard install so a
+		// fresh run is immediately usable from the right-click menu.
+		if err := installRegistry(registryScopeLocal, false); err != nil {
+			logging.L().WithError(err).Error("registry install failed")
+			dlgs.Error(AppName, fmt.Sprintf("Setup Error:\nFailed to update registry settings.\n%s", err))
+		} else {
+			logging.L().Info("registry installed")
+		}
 		return
 	}
 
 	// 3. Determine Execution Mode
 	var saveDir string
 
-	if len(os.Args) > 1 {
+	if len(args) > 0 {
 		// MODE A: User right-clicked a Folder Icon
-		saveDir = strings.TrimSpace(os.Args[1])
+		saveDir = strings.TrimSpace(args[0])
 	} else {
 		// MODE B: User right-clicked Background
 		if cfg.AutoSave {
@@ -75,10 +184,19 @@ func main() {
 	}
 
 	// 4. Get Clipboard
-	content, err := clipboard.ReadAll()
+	cb := clipboardx.New(cfg.ClipboardBackend, clipboardx.Options{
+		ReadCmd:  cfg.ClipboardReadCmd,
+		WriteCmd: cfg.ClipboardWriteCmd,
+	})
+	content, err := cb.Read()
+	if errors.Is(err, clipboardx.ErrUnsupported) {
+		content, err = clipboardx.Native().Read()
+	}
 	if err != nil || content == "" {
+		logging.L().WithError(err).Warn("clipboard read empty or failed")
 		return
 	}
+	logging.L().WithField("bytes", len(content)).Debug("clipboard read")
 
 	// 5. Parse & Sanitize
 	lines := strings.Split(content, "\n")
@@ -87,42 +205,122 @@ func main() {
 		return
 	}
 
-	// --- UPDATED: HANDLE MULTIPLE PREFIXES (SPLIT BY PIPE) ---
+	// Try the language-aware namer subsystem first; fall back to the
+	// original PrefixToStrip heuristic for content it doesn't recognize.
 	filenameRaw := firstLine
-	if cfg.PrefixToStrip != "" {
+	matchedPrefix := ""
+	extension := cfg.Extension
+	lang := ""
+
+	if derivedName, derivedExt, derivedLang, ok := namerRegistry(cfg).Derive(content); ok {
+		filenameRaw = derivedName
+		if extension == "" {
+			extension = derivedExt
+		}
+		lang = derivedLang
+		logging.L().WithField("name", derivedName).WithField("ext", derivedExt).Debug("namer matched")
+	} else if cfg.PrefixToStrip != "" {
+		// --- UPDATED: HANDLE MULTIPLE PREFIXES (SPLIT BY PIPE) ---
 		// Split config by pipe "|" to get all options
 		prefixes := strings.Split(cfg.PrefixToStrip, "|")
 		for _, p := range prefixes {
 			// Check if this specific prefix matches the start of the line
 			if p != "" && strings.HasPrefix(firstLine, p) {
 				filenameRaw = strings.TrimPrefix(firstLine, p)
+				matchedPrefix = p
 				break // Stop after finding the first match
 			}
 		}
+		logging.L().WithField("prefix", matchedPrefix).Debug("prefix match decision")
+	}
+
+	safeFilename := sanitizeFilename(strings.TrimSpace(filenameRaw)) + extension
+
+	// Reviewing lets the user fix a mis-derived filename or folder, and
+	// pick git options, before anything is written to disk. In review mode
+	// the dialog's toggles are the sole say over commit/push, so unchecking
+	// "git commit" there must be able to override GitAutoCommit, not just
+	// add to it.
+	doCommit, doPush := cfg.GitAutoCommit, false
+	reviewed := false
+	if review {
+		// dgGit is built with -H=windowsgui (see note above), so launched
+		// from Explorer it has no console for the TUI to attach to; give
+		// it one before starting bubbletea.
+		ensureConsole()
+
+		res, err := reviewui.Run(reviewui.Input{
+			Filename:      safeFilename,
+			Folder:        saveDir,
+			Content:       content,
+			Lang:          lang,
+			CommitDefault: cfg.GitAutoCommit,
+			PushDefault:   cfg.GitRemote != "",
+		})
+		switch {
+		case err != nil:
+			// No attached console, a non-interactive launch, or any other
+			// TUI failure shouldn't drop the clipboard content -- fall
+			// back to the plain, non-reviewed save instead of aborting.
+			logging.L().WithError(err).Warn("review dialog failed, falling back to plain save")
+		case res.Action == reviewui.ActionCancel:
+			logging.L().Info("review cancelled")
+			return
+		default:
+			reviewed = true
+			safeFilename = res.Filename
+			saveDir = res.Folder
+			doCommit = res.Action == reviewui.ActionSaveCommit || res.Action == reviewui.ActionSaveCommitPush
+			doPush = res.Action == reviewui.ActionSaveCommitPush
+		}
 	}
 
-	safeFilename := sanitizeFilename(strings.TrimSpace(filenameRaw)) + cfg.Extension
 	fullPath := filepath.Join(saveDir, safeFilename)
+	logging.L().WithField("filename", safeFilename).Info("derived filename")
 
 	// 6. Save File
 	err = os.WriteFile(fullPath, []byte(content), 0644)
 	if err != nil {
+		logging.L().WithError(err).Error("save file failed")
 		dlgs.Error(AppName, fmt.Sprintf("Error saving file:\n%s", err))
 		return
 	}
 
 	// 7. Git Auto-Commit
 	var gitMessage string
-	if cfg.GitAutoCommit {
-		if err := runGitCommit(saveDir, safeFilename); err != nil {
+	if doCommit {
+		backend := gitops.New(cfg.GitBackend, gitops.Options{
+			AutoInit: cfg.GitAutoInit,
+			Author:   cfg.GitAuthorName,
+			Email:    cfg.GitAuthorEmail,
+			Template: cfg.GitCommitTemplate,
+			Branch:   cfg.GitBranch,
+		})
+		batcher := &gitops.Batcher{
+			Backend: backend,
+			Remote:  cfg.GitRemote,
+			Branch:  cfg.GitBranch,
+			Window:  time.Duration(cfg.GitBatchWindowSeconds) * time.Second,
+			Every:   cfg.GitBatchCommits,
+		}
+		if doPush {
+			batcher.Every = 1 // push immediately, as chosen in the review dialog
+		} else if reviewed {
+			batcher.Remote = "" // the review dialog's choice left out pushing
+		}
+		if err := batcher.Commit(saveDir, safeFilename, matchedPrefix); err != nil {
+			logging.L().WithError(err).Error("git commit failed")
 			gitMessage = fmt.Sprintf("\n(Git Commit Failed: %s)", err)
 		} else {
+			logging.L().Info("git commit succeeded")
 			gitMessage = "\n(Git Commit Successful)"
 		}
 	}
 
 	// 8. Clear Clipboard
-	_ = clipboard.WriteAll("")
+	if err := cb.Write(""); errors.Is(err, clipboardx.ErrUnsupported) {
+		_ = clipboardx.Native().Write("")
+	}
 
 	// 9. Success Message
 	if cfg.ShowSuccess {
@@ -133,7 +331,7 @@ func main() {
 // --- SETUP WIZARD & CONFIG ---
 
 func loadConfig() (Config, bool, bool) {
-	path := filepath.Join(getExeDir(), ConfigFileName)
+	path := configPath()
 
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		cfg, cancelled := runSetupWizard(path)
@@ -147,6 +345,15 @@ func loadConfig() (Config, bool, bool) {
 		ShowSuccess:   true,
 		AutoSave:      false,
 		GitAutoCommit: false,
+
+		GitBackend:        "shell",
+		GitBranch:         "main",
+		GitCommitTemplate: "Auto-save: {{filename}} (via dgGit)",
+		GitBatchCommits:   1,
+
+		ClipboardBackend: "native",
+
+		LogLevel: "info",
 	}
 
 	file, err := os.Open(path)
@@ -181,6 +388,59 @@ func loadConfig() (Config, bool, bool) {
 			cfg.AutoSave = (val == "true")
 		case "gitautocommit":
 			cfg.GitAutoCommit = (val == "true")
+		case "gitbackend":
+			cfg.GitBackend = val
+		case "gitautoinit":
+			cfg.GitAutoInit = (val == "true")
+		case "gitauthorname":
+			cfg.GitAuthorName = val
+		case "gitauthoremail":
+			cfg.GitAuthorEmail = val
+		case "gitcommittemplate":
+			cfg.GitCommitTemplate = val
+		case "gitremote":
+			cfg.GitRemote = val
+		case "gitbranch":
+			cfg.GitBranch = val
+		case "gitbatchcommits":
+			if n, err := strconv.Atoi(val); err == nil {
+				cfg.GitBatchCommits = n
+			}
+		case "gitbatchwindowseconds":
+			if n, err := strconv.Atoi(val); err == nil {
+				cfg.GitBatchWindowSeconds = n
+			}
+		case "loglevel":
+			cfg.LogLevel = val
+		case "clipboardbackend":
+			cfg.ClipboardBackend = val
+		case "clipboardreadcmd":
+			cfg.ClipboardReadCmd = val
+		case "clipboardwritecmd":
+			cfg.ClipboardWriteCmd = val
+		default:
+			// "Namer.<lang>.Pattern=" / "Namer.<lang>.Extension=" blocks
+			// configure additional namer.RegexParser fallbacks.
+			if strings.HasPrefix(key, "namer.") {
+				fields := strings.SplitN(strings.TrimPrefix(key, "namer."), ".", 2)
+				if len(fields) == 2 {
+					lang := fields[0]
+					if cfg.NamerRules == nil {
+						cfg.NamerRules = map[string]*NamerRule{}
+					}
+					rule, ok := cfg.NamerRules[lang]
+					if !ok {
+						rule = &NamerRule{}
+						cfg.NamerRules[lang] = rule
+					}
+					switch fields[1] {
+					case "pattern":
+						rule.Pattern = val
+					case "extension":
+						rule.Extension = val
+					}
+				}
+			}
 		}
 	}
 	return cfg, false, false
@@ -241,6 +501,15 @@ func runSetupWizard(configPath string) (Config, bool) {
 		ShowSuccess:   true,
 		AutoSave:      autoSave,
 		GitAutoCommit: useGit,
+
+		GitBackend:        "shell",
+		GitBranch:         "main",
+		GitCommitTemplate: "Auto-save: {{filename}} (via dgGit)",
+		GitBatchCommits:   1,
+
+		ClipboardBackend: "native",
+
+		LogLevel: "info",
 	}
 
 	saveConfigToFile(configPath, newCfg)
@@ -286,7 +555,46 @@ AutoSave=%t
 # Automatically run 'git add' and 'git commit' after saving? (true/false)
 # Note: Git must be installed and the target folder must be a git repo.
 GitAutoCommit=%t
-`, cfg.StartDir, cfg.Extension, cfg.PrefixToStrip, cfg.ShowSuccess, cfg.AutoSave, cfg.GitAutoCommit)
+
+# 7. Git Backend (advanced)
+# GitBackend: "shell" (shells out to the git binary) or "gogit" (in-process, no git install required).
+GitBackend=%s
+# Auto-init a new repo in the save folder if it isn't one yet? (true/false)
+GitAutoInit=%t
+# Optional commit author override; leave blank to use git's own default (the gogit backend then uses "dgGit <dggit@localhost>").
+GitAuthorName=%s
+GitAuthorEmail=%s
+# Commit message template. Placeholders: {{filename}}, {{date}}, {{prefix}}
+GitCommitTemplate=%s
+# Remote and branch to push to after a batch of commits; leave GitRemote blank to disable pushing.
+GitRemote=%s
+GitBranch=%s
+# Push after this many commits, and/or after this many seconds since the last push (0 disables either trigger).
+GitBatchCommits=%d
+GitBatchWindowSeconds=%d
+
+# 8. Clipboard Backend (advanced)
+# ClipboardBackend: "native" (default), "shell" (ClipboardReadCmd/ClipboardWriteCmd), or "osc52" (clear via terminal escape sequence, for SSH sessions).
+ClipboardBackend=%s
+# Shell commands for the "shell" backend. Use {{text}} in ClipboardWriteCmd as a placeholder for the text to write.
+ClipboardReadCmd=%s
+ClipboardWriteCmd=%s
+
+# 9. Filename Detection (advanced)
+# Built-in parsers already recognize Go, Python, JavaScript, shell and C
+# declarations. Add more fallback parsers with repeated blocks like:
+#   Namer.ruby.Pattern=^def\s+([A-Za-z_][A-Za-z0-9_]*)
+#   Namer.ruby.Extension=.rb
+# Leave Extension above blank to let the detected language pick the extension.
+
+# 10. Logging
+# Level for the log file at %%LOCALAPPDATA%%\dgGit\dggit.log: debug, info, warn or error.
+# Run with --debug to force debug level and also mirror log output to stderr for this run.
+LogLevel=%s
+`, cfg.StartDir, cfg.Extension, cfg.PrefixToStrip, cfg.ShowSuccess, cfg.AutoSave, cfg.GitAutoCommit,
+		cfg.GitBackend, cfg.GitAutoInit, cfg.GitAuthorName, cfg.GitAuthorEmail, cfg.GitCommitTemplate,
+		cfg.GitRemote, cfg.GitBranch, cfg.GitBatchCommits, cfg.GitBatchWindowSeconds,
+		cfg.ClipboardBackend, cfg.ClipboardReadCmd, cfg.ClipboardWriteCmd, cfg.LogLevel)
 
 	os.WriteFile(path, []byte(content), 0644)
 }
@@ -307,29 +615,37 @@ func createDesktopShortcut() error {
 	psScript := fmt.Sprintf("$s=(New-Object -COM WScript.Shell).CreateShortcut('%s');$s.TargetPath='%s';$s.Save()", desktopPath, exePath)
 
 	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", psScript)
-	return cmd.Run()
+	err = cmd.Run()
+	logging.L().WithField("path", desktopPath).WithError(err).Debug("desktop shortcut created")
+	return err
 }
 
 // --- HELPERS ---
 
-func runGitCommit(dir, filename string) error {
-	if _, err := exec.LookPath("git"); err != nil {
-		return fmt.Errorf("git not found")
-	}
-	cmdAdd := exec.Command("git", "add", filename)
-	cmdAdd.Dir = dir
-	if out, err := cmdAdd.CombinedOutput(); err != nil {
-		return fmt.Errorf("add: %s", string(out))
-	}
+// kernel32 / procAllocConsole back ensureConsole. golang.org/x/sys/windows
+// doesn't wrap AllocConsole, so it's loaded directly from kernel32.dll via
+// syscall.NewLazyDLL.
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procAllocConsole = kernel32.NewProc("AllocConsole")
+)
 
-	msg := fmt.Sprintf("Auto-save: %s (via dgGit)", filename)
+// ensureConsole attaches a console to the current process and repoints
+// os.Stdin/Stdout/Stderr at it. dgGit is built with -H=windowsgui (see the
+// note above main), so it normally has none; --review needs one for
+// bubbletea to read keys and draw into. When a console is already attached,
+// e.g. running from a terminal, AllocConsole just fails and the std handles
+// are repointed at that existing console.
+func ensureConsole() {
+	procAllocConsole.Call()
 
-	cmdCommit := exec.Command("git", "commit", "-m", msg)
-	cmdCommit.Dir = dir
-	if out, err := cmdCommit.CombinedOutput(); err != nil {
-		return fmt.Errorf("commit: %s", string(out))
+	if f, err := os.OpenFile("CONIN$", os.O_RDWR, 0); err == nil {
+		os.Stdin = f
+	}
+	if f, err := os.OpenFile("CONOUT$", os.O_RDWR, 0); err == nil {
+		os.Stdout = f
+		os.Stderr = f
 	}
-	return nil
 }
 
 func askForFolder(startDir string) string {
@@ -346,6 +662,24 @@ func askForFolder(startDir string) string {
 	return dir
 }
 
+// namerRegistry builds the parser registry used to derive a filename and
+// extension from clipboard content: the built-in language parsers, plus any
+// "Namer.<lang>.Pattern" fallbacks the user configured.
+func namerRegistry(cfg Config) *namer.Registry {
+	registry := namer.Default()
+	for lang, rule := range cfg.NamerRules {
+		if rule.Pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		registry.Add(namer.RegexParser{LangName: lang, Pattern: re, Ext: rule.Extension})
+	}
+	return registry
+}
+
 func sanitizeFilename(name string) string {
 	illegalChars := []string{"<", ">", ":", "\"", "/", "\\", "|", "?", "*"}
 	for _, char := range illegalChars {
@@ -362,48 +696,115 @@ func getExeDir() string {
 	return filepath.Dir(exe)
 }
 
+func configPath() string {
+	return filepath.Join(getExeDir(), ConfigFileName)
+}
+
 // --- REGISTRY ---
 
-func updateRegistry() error {
+// registryScope selects which registry hive install/uninstall operate on:
+// per-user (HKCU, no elevation needed) or machine-wide (HKLM, requires an
+// elevated process).
+type registryScope int
+
+const (
+	registryScopeLocal registryScope = iota
+	registryScopeSystem
+)
+
+func (s registryScope) rootKey() registry.Key {
+	if s == registryScopeSystem {
+		return registry.LOCAL_MACHINE
+	}
+	return registry.CURRENT_USER
+}
+
+// reviewMenuName and ignoreMenuName are the registry key names for the
+// "Review..." and "Ignore" entries, kept distinct from AppName so all three
+// entries can coexist.
+const reviewMenuName = AppName + " (Review...)"
+const ignoreMenuName = AppName + " (Ignore)"
+
+// installRegistry writes the Explorer right-click entries for the
+// folder-background and folder-icon cases (save and save --review), plus an
+// "Ignore" entry on both folders and files that appends the clicked item to
+// its containing directory's .gitignore. An existing command value is left
+// as it is unless force is set, which rewrites it; useful after changing
+// how the exe is invoked.
+func installRegistry(scope registryScope, force bool) error {
 	exePath, err := os.Executable()
 	if err != nil {
 		return err
 	}
+	root := scope.rootKey()
+	logging.L().WithField("exe", exePath).WithField("system", scope == registryScopeSystem).Debug("writing registry entries")
 
-	if err := setMenuKey(`Software\Classes\Directory\Background\shell`, exePath, false); err != nil {
+	for _, base := range []string{`Software\Classes\Directory\Background\shell`, `Software\Classes\Directory\shell`} {
+		passArg := base == `Software\Classes\Directory\shell`
+		if err := setMenuKey(root, base, AppName, exePath, "save", passArg, force); err != nil {
+			return err
+		}
+		if err := setMenuKey(root, base, reviewMenuName, exePath, "save --review", passArg, force); err != nil {
+			return err
+		}
+	}
+	if err := setMenuKey(root, `Software\Classes\Directory\shell`, ignoreMenuName, exePath, "ignore", true, force); err != nil {
 		return err
 	}
-	if err := setMenuKey(`Software\Classes\Directory\shell`, exePath, true); err != nil {
+	if err := setMenuKey(root, `Software\Classes\*\shell`, ignoreMenuName, exePath, "ignore", true, force); err != nil {
 		return err
 	}
+	return nil
+}
+
+// uninstallRegistry removes every entry installRegistry can create, from
+// both the local (HKCU) and system (HKLM) scopes.
+func uninstallRegistry() error {
+	for _, root := range []registry.Key{registry.CURRENT_USER, registry.LOCAL_MACHINE} {
+		for _, base := range []string{`Software\Classes\Directory\Background\shell`, `Software\Classes\Directory\shell`} {
+			for _, name := range []string{AppName, reviewMenuName} {
+				keyPath := base + `\` + name
+				_ = registry.DeleteKey(root, keyPath+`\command`)
+				_ = registry.DeleteKey(root, keyPath)
+			}
+		}
+
+		for _, base := range []string{`Software\Classes\Directory\shell`, `Software\Classes\*\shell`} {
+			keyPath := base + `\` + ignoreMenuName
+			_ = registry.DeleteKey(root, keyPath+`\command`)
+			_ = registry.DeleteKey(root, keyPath)
+		}
 
-	fileKeyPath := `Software\Classes\*\shell\` + AppName
-	_ = registry.DeleteKey(registry.CURRENT_USER, fileKeyPath+`\command`)
-	_ = registry.DeleteKey(registry.CURRENT_USER, fileKeyPath)
+		fileKeyPath := `Software\Classes\*\shell\` + AppName
+		_ = registry.DeleteKey(root, fileKeyPath+`\command`)
+		_ = registry.DeleteKey(root, fileKeyPath)
+	}
 	return nil
 }
 
-func setMenuKey(basePath, exePath string, passArg bool) error {
-	keyPath := basePath + `\` + AppName
+// setMenuKey writes one Explorer context-menu entry named name, running
+// exePath with args (plus the clicked folder as "%1" when passArg is set).
+func setMenuKey(root registry.Key, basePath, name, exePath, args string, passArg, force bool) error {
+	keyPath := basePath + `\` + name
 	cmdPath := keyPath + `\command`
-	k, _, err := registry.CreateKey(registry.CURRENT_USER, keyPath, registry.ALL_ACCESS)
+	k, _, err := registry.CreateKey(root, keyPath, registry.ALL_ACCESS)
 	if err != nil {
 		return err
 	}
 	defer k.Close()
-	k.SetStringValue("", AppName)
+	k.SetStringValue("", name)
 	k.SetStringValue("Icon", "shell32.dll,259")
-	ck, _, err := registry.CreateKey(registry.CURRENT_USER, cmdPath, registry.ALL_ACCESS)
+	ck, _, err := registry.CreateKey(root, cmdPath, registry.ALL_ACCESS)
 	if err != nil {
 		return err
 	}
 	defer ck.Close()
-	cmdStr := fmt.Sprintf(`"%s"`, exePath)
+	cmdStr := fmt.Sprintf(`"%s" %s`, exePath, args)
 	if passArg {
-		cmdStr = fmt.Sprintf(`"%s" "%%1"`, exePath)
+		cmdStr = fmt.Sprintf(`"%s" %s "%%1"`, exePath, args)
 	}
 	curr, _, _ := ck.GetStringValue("")
-	if curr != cmdStr {
+	if force || curr == "" {
 		ck.SetStringValue("", cmdStr)
 	}
 	return nil
@@ -0,0 +1,62 @@
+// Package clipboardx provides dgGit's pluggable clipboard access. Previously
+// the tool only ever called github.com/atotto/clipboard directly; that is
+// still the default (native) backend here, alongside a shell-out backend for
+// exotic setups (Wayland, tmux, remote sessions) and an OSC52 writer for
+// clearing the clipboard over SSH.
+package clipboardx
+
+import "errors"
+
+// ErrUnsupported is returned by a backend that cannot perform the requested
+// operation on the current platform/session, so callers can fall back to
+// another backend instead of failing outright.
+var ErrUnsupported = errors.New("clipboardx: unsupported")
+
+// Reader reads the current clipboard contents.
+type Reader interface {
+	Read() (string, error)
+}
+
+// Writer replaces the current clipboard contents.
+type Writer interface {
+	Write(text string) error
+}
+
+// Clipboard combines a Reader and Writer; most callers want both.
+type Clipboard interface {
+	Reader
+	Writer
+}
+
+type combined struct {
+	Reader
+	Writer
+}
+
+// Options configures the backends New selects between.
+type Options struct {
+	ReadCmd  string
+	WriteCmd string
+}
+
+// New returns the Clipboard backend selected by name ("native", "shell" or
+// "osc52"), defaulting to native for unknown or empty values.
+func New(name string, opts Options) Clipboard {
+	switch name {
+	case "shell":
+		return combined{
+			Reader: shellClipboard{ReadCmd: opts.ReadCmd},
+			Writer: shellClipboard{WriteCmd: opts.WriteCmd},
+		}
+	case "osc52":
+		return combined{Reader: nativeClipboard{}, Writer: osc52Clipboard{}}
+	default:
+		return Native()
+	}
+}
+
+// Native returns the OS-native clipboard backend. Useful as an explicit
+// fallback when another backend reports ErrUnsupported.
+func Native() Clipboard {
+	return combined{Reader: nativeClipboard{}, Writer: nativeClipboard{}}
+}
@@ -0,0 +1,11 @@
+package clipboardx
+
+import "github.com/atotto/clipboard"
+
+// nativeClipboard is the default backend, backed by the OS clipboard APIs
+// via github.com/atotto/clipboard.
+type nativeClipboard struct{}
+
+func (nativeClipboard) Read() (string, error) { return clipboard.ReadAll() }
+
+func (nativeClipboard) Write(text string) error { return clipboard.WriteAll(text) }
@@ -0,0 +1,35 @@
+package clipboardx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+)
+
+// osc52Clipboard writes the clipboard over an OSC52 terminal escape
+// sequence, so "clearing" the clipboard still works when dgGit is launched
+// from an SSH session or a terminal the OS clipboard APIs can't reach.
+// Reading is not part of the OSC52 protocol.
+type osc52Clipboard struct{}
+
+func (osc52Clipboard) Read() (string, error) {
+	return "", ErrUnsupported
+}
+
+func (osc52Clipboard) Write(text string) error {
+	tty, err := openTTY()
+	if err != nil {
+		return fmt.Errorf("clipboardx: %w", err)
+	}
+	defer tty.Close()
+
+	_, err = fmt.Fprintf(tty, "\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
+
+func openTTY() (*os.File, error) {
+	if f, err := os.OpenFile("CONOUT$", os.O_WRONLY, 0); err == nil {
+		return f, nil
+	}
+	return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+}
@@ -0,0 +1,45 @@
+package clipboardx
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// shellClipboard drives clipboard access through user-configured commands,
+// via the ClipboardReadCmd / ClipboardWriteCmd config keys. WriteCmd's
+// "{{text}}" placeholder is replaced with the text to write.
+type shellClipboard struct {
+	ReadCmd  string
+	WriteCmd string
+}
+
+func (s shellClipboard) Read() (string, error) {
+	if s.ReadCmd == "" {
+		return "", ErrUnsupported
+	}
+	out, err := runShell(s.ReadCmd)
+	if err != nil {
+		return "", fmt.Errorf("clipboardx: read command: %w", err)
+	}
+	return out, nil
+}
+
+func (s shellClipboard) Write(text string) error {
+	if s.WriteCmd == "" {
+		return ErrUnsupported
+	}
+	if _, err := runShell(strings.ReplaceAll(s.WriteCmd, "{{text}}", text)); err != nil {
+		return fmt.Errorf("clipboardx: write command: %w", err)
+	}
+	return nil
+}
+
+func runShell(cmdStr string) (string, error) {
+	cmd := exec.Command("cmd", "/C", cmdStr)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	err := cmd.Run()
+	return out.String(), err
+}
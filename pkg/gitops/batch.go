@@ -0,0 +1,98 @@
+package gitops
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Batcher wraps a Backend so that a configured number of commits, or a
+// configured time window, elapses before the accumulated commits are pushed
+// to the configured remote. This keeps rapid clipboard saves from
+// triggering a push per file.
+//
+// Every dgGit run is its own process (one save = one invocation), so the
+// pending-commit count and last-push time are persisted to a small
+// per-directory state file (see batchStatePath) rather than kept in memory.
+type Batcher struct {
+	Backend Backend
+	Remote  string
+	Branch  string
+	Window  time.Duration
+	Every   int
+}
+
+func (b *Batcher) Commit(dir, filename, matchedPrefix string) error {
+	if err := b.Backend.Commit(dir, filename, matchedPrefix); err != nil {
+		return err
+	}
+
+	if b.Remote == "" {
+		return nil
+	}
+	pusher, ok := b.Backend.(Pushable)
+	if !ok {
+		return nil
+	}
+
+	state := loadBatchState(dir)
+	state.Pending++
+
+	due := (b.Every > 0 && state.Pending >= b.Every) ||
+		(b.Window > 0 && !state.LastPush.IsZero() && time.Since(state.LastPush) >= b.Window)
+
+	if !due {
+		saveBatchState(dir, state)
+		return nil
+	}
+
+	state.Pending = 0
+	state.LastPush = time.Now()
+	saveBatchState(dir, state)
+
+	return pusher.Push(dir, b.Remote, b.Branch)
+}
+
+type batchState struct {
+	Pending  int       `json:"pending"`
+	LastPush time.Time `json:"last_push"`
+}
+
+// batchStatePath keeps the batch state under %LOCALAPPDATA%, like
+// pkg/logging's log file, rather than inside the save directory -- dir is
+// usually the user's repo, and dropping an untracked file into it would
+// litter every target repo dgGit touches. State is keyed by a hash of dir
+// since more than one save directory can be in use.
+func batchStatePath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	sum := sha1.Sum([]byte(abs))
+	base := filepath.Join(os.Getenv("LOCALAPPDATA"), "dgGit", "batch")
+	_ = os.MkdirAll(base, 0755)
+	return filepath.Join(base, hex.EncodeToString(sum[:])+".json")
+}
+
+func loadBatchState(dir string) batchState {
+	data, err := os.ReadFile(batchStatePath(dir))
+	if err != nil {
+		return batchState{LastPush: time.Now()}
+	}
+	var s batchState
+	if err := json.Unmarshal(data, &s); err != nil {
+		return batchState{LastPush: time.Now()}
+	}
+	return s
+}
+
+func saveBatchState(dir string, s batchState) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(batchStatePath(dir), data, 0644)
+}
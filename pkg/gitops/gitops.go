@@ -0,0 +1,53 @@
+// Package gitops provides the pluggable git integration dgGit uses to
+// commit (and optionally push) a saved file. main.go previously shelled out
+// to the git binary directly via runGitCommit; that behavior now lives here
+// as one of two interchangeable Backend implementations.
+package gitops
+
+// Backend is implemented by every git integration strategy dgGit can use to
+// persist a saved file into a repository.
+type Backend interface {
+	// Commit stages filename within dir and commits it according to the
+	// backend's configured message template. matchedPrefix is whatever
+	// PrefixToStrip matched for this save, or "" when none did (including
+	// when a namer parser derived the name); it's available to the
+	// {{prefix}} template placeholder.
+	// Commit creates the repo first when AutoInit is set and dir isn't
+	// already one.
+	Commit(dir, filename, matchedPrefix string) error
+}
+
+// Pushable is implemented by backends that can push commits to a remote
+// directly. Not every Backend needs to support this, so it's a separate,
+// optional interface rather than part of Backend itself.
+type Pushable interface {
+	Push(dir, remote, branch string) error
+}
+
+// Options configures the behavior shared by every Backend implementation.
+type Options struct {
+	AutoInit bool
+	Author   string
+	Email    string
+	Template string // e.g. "Auto-save: {{filename}} ({{date}})"
+
+	// Branch is the branch AutoInit creates the repo on, matching the
+	// GitBranch config value Push later pushes to. Empty means whatever the
+	// backend's own default is (git's init.defaultBranch, usually "master").
+	Branch string
+}
+
+// New returns the Backend selected by name ("shell" or "gogit"), defaulting
+// to the shell backend for unknown or empty values so existing configs keep
+// working unchanged.
+func New(name string, opts Options) Backend {
+	switch name {
+	case "gogit":
+		return newGoGitBackend(opts)
+	default:
+		return newShellBackend(opts)
+	}
+}
+
+// defaultTemplate is used whenever Options.Template is empty.
+const defaultTemplate = "Auto-save: {{filename}} (via dgGit)"
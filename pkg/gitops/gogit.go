@@ -0,0 +1,122 @@
+package gitops
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// goGitBackend commits in-process via go-git instead of shelling out to the
+// git binary, so dgGit keeps working on machines without git on PATH.
+type goGitBackend struct {
+	opts Options
+}
+
+func newGoGitBackend(opts Options) *goGitBackend {
+	return &goGitBackend{opts: opts}
+}
+
+func (b *goGitBackend) open(dir string) (*git.Repository, error) {
+	// DetectDotGit walks up from dir looking for a .git, so a save dir that's
+	// a subdirectory of an existing repo resolves to that repo instead of
+	// reporting ErrRepositoryNotExists -- matching the shell backend, which
+	// relies on "git rev-parse --is-inside-work-tree" doing the same walk.
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err == nil {
+		return repo, nil
+	}
+	if err != git.ErrRepositoryNotExists || !b.opts.AutoInit {
+		return nil, err
+	}
+
+	repo, err = git.PlainInit(dir, false)
+	if err != nil {
+		return nil, err
+	}
+	// PlainInit always points HEAD at refs/heads/master; move it to the
+	// configured branch so Push's refspec has a local ref to push.
+	if b.opts.Branch != "" && b.opts.Branch != "master" {
+		head := plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.ReferenceName("refs/heads/"+b.opts.Branch))
+		if err := repo.Storer.SetReference(head); err != nil {
+			return nil, fmt.Errorf("set branch: %w", err)
+		}
+	}
+	return repo, nil
+}
+
+func (b *goGitBackend) Commit(dir, filename, matchedPrefix string) error {
+	repo, err := b.open(dir)
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("worktree: %w", err)
+	}
+	if _, err := wt.Add(repoRelativePath(wt, dir, filename)); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+
+	author := b.opts.Author
+	if author == "" {
+		author = "dgGit"
+	}
+	email := b.opts.Email
+	if email == "" {
+		email = "dggit@localhost"
+	}
+
+	_, err = wt.Commit(renderTemplate(b.opts.Template, filename, matchedPrefix), &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  author,
+			Email: email,
+			When:  time.Now(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
+
+// repoRelativePath returns filename's path relative to wt's root, so Add
+// still works when dir (the save directory) is a subdirectory of the
+// repository rather than its root.
+func repoRelativePath(wt *git.Worktree, dir, filename string) string {
+	root, ok := wt.Filesystem.(interface{ Root() string })
+	if !ok {
+		return filename
+	}
+	abs, err := filepath.Abs(filepath.Join(dir, filename))
+	if err != nil {
+		return filename
+	}
+	rel, err := filepath.Rel(root.Root(), abs)
+	if err != nil {
+		return filename
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (b *goGitBackend) Push(dir, remote, branch string) error {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+
+	err = repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", branch, branch))},
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("push: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AppendIgnore adds pattern to dir's .gitignore, creating the file if it
+// doesn't exist yet and skipping patterns that are already present.
+func AppendIgnore(dir, pattern string) error {
+	path := filepath.Join(dir, ".gitignore")
+
+	existing, _ := os.ReadFile(path)
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		pattern = "\n" + pattern
+	}
+	_, err = f.WriteString(pattern + "\n")
+	return err
+}
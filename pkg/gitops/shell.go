@@ -0,0 +1,67 @@
+package gitops
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// shellBackend drives git via the git CLI. This is the original behavior
+// dgGit had before Backend existed, now just one implementation of it.
+type shellBackend struct {
+	opts Options
+}
+
+func newShellBackend(opts Options) *shellBackend {
+	return &shellBackend{opts: opts}
+}
+
+func (b *shellBackend) Commit(dir, filename, matchedPrefix string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git not found")
+	}
+
+	if b.opts.AutoInit && !isRepo(dir) {
+		initArgs := []string{"init"}
+		if b.opts.Branch != "" {
+			// Match the branch Push will later push to, rather than
+			// whatever git's init.defaultBranch happens to be.
+			initArgs = append(initArgs, "-b", b.opts.Branch)
+		}
+		if out, err := runGit(dir, initArgs...); err != nil {
+			return fmt.Errorf("init: %s", out)
+		}
+	}
+
+	if out, err := runGit(dir, "add", filename); err != nil {
+		return fmt.Errorf("add: %s", out)
+	}
+
+	args := []string{"commit", "-m", renderTemplate(b.opts.Template, filename, matchedPrefix)}
+	if b.opts.Author != "" {
+		args = append(args, "--author", fmt.Sprintf("%s <%s>", b.opts.Author, b.opts.Email))
+	}
+	if out, err := runGit(dir, args...); err != nil {
+		return fmt.Errorf("commit: %s", out)
+	}
+
+	return nil
+}
+
+func (b *shellBackend) Push(dir, remote, branch string) error {
+	if out, err := runGit(dir, "push", remote, branch); err != nil {
+		return fmt.Errorf("push: %s", out)
+	}
+	return nil
+}
+
+func isRepo(dir string) bool {
+	_, err := runGit(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
@@ -0,0 +1,20 @@
+package gitops
+
+import (
+	"strings"
+	"time"
+)
+
+// renderTemplate expands the "{{filename}}", "{{date}}" and "{{prefix}}"
+// placeholders in a commit message template.
+func renderTemplate(tmpl, filename, matchedPrefix string) string {
+	if tmpl == "" {
+		tmpl = defaultTemplate
+	}
+	r := strings.NewReplacer(
+		"{{filename}}", filename,
+		"{{date}}", time.Now().Format("2006-01-02 15:04:05"),
+		"{{prefix}}", matchedPrefix,
+	)
+	return r.Replace(tmpl)
+}
@@ -0,0 +1,52 @@
+package gitops
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	cases := []struct {
+		name          string
+		tmpl          string
+		filename      string
+		matchedPrefix string
+		want          string
+	}{
+		{
+			name:     "empty template falls back to defaultTemplate",
+			tmpl:     "",
+			filename: "foo.go",
+			want:     renderTemplate(defaultTemplate, "foo.go", ""),
+		},
+		{
+			name:          "expands filename and prefix placeholders",
+			tmpl:          "save {{filename}} ({{prefix}})",
+			filename:      "bar.py",
+			matchedPrefix: "TODO",
+			want:          "save bar.py (TODO)",
+		},
+		{
+			name:     "unknown placeholders are left alone",
+			tmpl:     "{{unknown}} {{filename}}",
+			filename: "baz.sh",
+			want:     "{{unknown}} baz.sh",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := renderTemplate(tc.tmpl, tc.filename, tc.matchedPrefix)
+			if got != tc.want {
+				t.Errorf("renderTemplate(%q, %q, %q) = %q, want %q", tc.tmpl, tc.filename, tc.matchedPrefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateExpandsDate(t *testing.T) {
+	got := renderTemplate("{{date}}", "f.go", "")
+	if got == "{{date}}" || strings.TrimSpace(got) == "" {
+		t.Errorf("renderTemplate did not expand {{date}}, got %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+// Package logging gives dgGit a persistent log file. The app is built with
+// -H=windowsgui, so without this, any error that doesn't trigger a dialog
+// (e.g. clipboard.ReadAll returning empty) vanishes with no way to diagnose
+// it short of rebuilding with a console attached.
+package logging
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxLogBytes is the size at which Init rotates the previous log out of the
+// way, keeping just one backup -- enough to diagnose a recent run without
+// the log file growing unbounded.
+const maxLogBytes = 5 * 1024 * 1024
+
+var log = logrus.New()
+
+// Init opens (rotating if necessary) "%LOCALAPPDATA%\<appName>\dggit.log" at
+// the given level. When mirrorStderr is set (dgGit was launched with
+// --debug from a console), log output also goes to stderr.
+func Init(level, appName string, mirrorStderr bool) error {
+	dir := filepath.Join(os.Getenv("LOCALAPPDATA"), appName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, "dggit.log")
+	rotate(path)
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	log.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	log.SetLevel(parseLevel(level))
+
+	if mirrorStderr {
+		log.SetOutput(io.MultiWriter(f, os.Stderr))
+	} else {
+		log.SetOutput(f)
+	}
+
+	return nil
+}
+
+// L returns the shared logger so call sites can attach structured fields,
+// e.g. logging.L().WithField("bytes", len(content)).Debug("clipboard read").
+func L() *logrus.Entry {
+	return logrus.NewEntry(log)
+}
+
+func parseLevel(level string) logrus.Level {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+	return lvl
+}
+
+func rotate(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < maxLogBytes {
+		return
+	}
+	_ = os.Remove(path + ".1")
+	_ = os.Rename(path, path+".1")
+}
@@ -0,0 +1,22 @@
+package namer
+
+import "regexp"
+
+// cFuncRe matches a C-style function signature: optional storage-class/type
+// modifiers, a return type, then the identifier and an opening paren. It
+// intentionally doesn't validate the parameter list.
+var cFuncRe = regexp.MustCompile(`^(?:static\s+|inline\s+|const\s+|unsigned\s+|signed\s+)*[A-Za-z_][A-Za-z0-9_]*(?:\s+\*?\s*|\s*\*\s*)([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// CParser recognizes C-style function signatures, e.g. "static int Foo(".
+type CParser struct{}
+
+func (CParser) Lang() string      { return "c" }
+func (CParser) Extension() string { return ".c" }
+
+func (CParser) Name(content string) (string, bool) {
+	m := cFuncRe.FindStringSubmatch(firstCodeLine(content))
+	if m == nil {
+		return "", false
+	}
+	return m[len(m)-1], true
+}
@@ -0,0 +1,65 @@
+package namer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// overrideRe matches the magic first-line hint "// dggit:lang=go" (or
+// "# dggit:lang=python", etc.) that forces parser selection regardless of
+// fingerprinting.
+var overrideRe = regexp.MustCompile(`dggit:lang=([A-Za-z0-9_]+)`)
+
+func overrideLang(content string) string {
+	m := overrideRe.FindStringSubmatch(firstNonBlankLine(content))
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func shebangLang(content string) string {
+	first := firstNonBlankLine(content)
+	if !strings.HasPrefix(first, "#!") {
+		return ""
+	}
+	switch {
+	case strings.Contains(first, "python"):
+		return "python"
+	case strings.Contains(first, "node"):
+		return "javascript"
+	case strings.Contains(first, "sh"):
+		return "shell"
+	}
+	return ""
+}
+
+func firstNonBlankLine(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\r", ""))
+		if line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// firstCodeLine is what parsers match against: the first non-blank line,
+// skipping a single leading "// dggit:lang=..." override comment or "#!"
+// shebang if present. Without this, parsers would try to extract a name from
+// the hint line itself instead of the declaration it precedes.
+func firstCodeLine(content string) string {
+	skippedHint := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\r", ""))
+		if line == "" {
+			continue
+		}
+		if !skippedHint && (overrideRe.MatchString(line) || strings.HasPrefix(line, "#!")) {
+			skippedHint = true
+			continue
+		}
+		return line
+	}
+	return ""
+}
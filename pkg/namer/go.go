@@ -0,0 +1,21 @@
+package namer
+
+import "regexp"
+
+// goFuncRe matches a Go function or method declaration, e.g.
+// "func Name(" or "func (s *Server) Name(".
+var goFuncRe = regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// GoParser recognizes Go function declarations.
+type GoParser struct{}
+
+func (GoParser) Lang() string      { return "go" }
+func (GoParser) Extension() string { return ".go" }
+
+func (GoParser) Name(content string) (string, bool) {
+	m := goFuncRe.FindStringSubmatch(firstCodeLine(content))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
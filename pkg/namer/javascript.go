@@ -0,0 +1,26 @@
+package namer
+
+import "regexp"
+
+var (
+	jsFunctionRe = regexp.MustCompile(`^function\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*\(`)
+	jsConstRe    = regexp.MustCompile(`^const\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*=\s*\(`)
+	jsClassRe    = regexp.MustCompile(`^class\s+([A-Za-z_$][A-Za-z0-9_$]*)`)
+)
+
+// JavaScriptParser recognizes named function declarations, arrow-function
+// const bindings, and class declarations.
+type JavaScriptParser struct{}
+
+func (JavaScriptParser) Lang() string      { return "javascript" }
+func (JavaScriptParser) Extension() string { return ".js" }
+
+func (JavaScriptParser) Name(content string) (string, bool) {
+	line := firstCodeLine(content)
+	for _, re := range []*regexp.Regexp{jsFunctionRe, jsConstRe, jsClassRe} {
+		if m := re.FindStringSubmatch(line); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,81 @@
+// Package namer derives a filename and extension from clipboard content,
+// replacing the single-line PrefixToStrip heuristic with language-aware
+// parsers. main.go still falls back to PrefixToStrip when no parser
+// recognizes the content, so existing configs keep working.
+package namer
+
+// Parser derives a filename (without extension) from clipboard content it
+// recognizes.
+type Parser interface {
+	// Name returns the derived filename and true if this parser recognized
+	// the content and could extract a name from it.
+	Name(content string) (name string, ok bool)
+	// Extension is the file extension (including the leading dot) this
+	// parser's language normally uses.
+	Extension() string
+	// Lang is the short identifier used in "Namer.<lang>.*" config keys and
+	// the "// dggit:lang=" override comment.
+	Lang() string
+}
+
+// Registry holds the parsers dgGit tries, in priority order, plus any
+// user-defined fallback parsers loaded from dggit.cfg.
+type Registry struct {
+	Parsers []Parser
+}
+
+// Default returns the built-in parser set, in the order they're tried.
+func Default() *Registry {
+	return &Registry{Parsers: []Parser{
+		GoParser{},
+		PythonParser{},
+		JavaScriptParser{},
+		ShellParser{},
+		CParser{},
+	}}
+}
+
+// Add registers an additional parser, e.g. a RegexParser loaded from config.
+func (r *Registry) Add(p Parser) {
+	r.Parsers = append(r.Parsers, p)
+}
+
+// Derive picks a filename, extension and language for content. It honors a
+// "// dggit:lang=<lang>" override on the first line, then a shebang check,
+// then tries each registered parser in order. ok is false when nothing
+// recognized the content, in which case callers should fall back to their
+// own default naming.
+func (r *Registry) Derive(content string) (name, ext, lang string, ok bool) {
+	if l := overrideLang(content); l != "" {
+		if p := r.byLang(l); p != nil {
+			if name, ok := p.Name(content); ok {
+				return name, p.Extension(), p.Lang(), true
+			}
+		}
+	}
+
+	if l := shebangLang(content); l != "" {
+		if p := r.byLang(l); p != nil {
+			if name, ok := p.Name(content); ok {
+				return name, p.Extension(), p.Lang(), true
+			}
+		}
+	}
+
+	for _, p := range r.Parsers {
+		if name, ok := p.Name(content); ok {
+			return name, p.Extension(), p.Lang(), true
+		}
+	}
+
+	return "", "", "", false
+}
+
+func (r *Registry) byLang(lang string) Parser {
+	for _, p := range r.Parsers {
+		if p.Lang() == lang {
+			return p
+		}
+	}
+	return nil
+}
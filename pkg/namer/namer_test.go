@@ -0,0 +1,73 @@
+package namer
+
+import "testing"
+
+func TestRegistryDerive(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		wantName string
+		wantExt  string
+		wantLang string
+		wantOk   bool
+	}{
+		{
+			name:     "go function",
+			content:  "func HandleRequest(w http.ResponseWriter, r *http.Request) {\n}\n",
+			wantName: "HandleRequest",
+			wantExt:  ".go",
+			wantLang: "go",
+			wantOk:   true,
+		},
+		{
+			name:     "python def",
+			content:  "def parse_config(path):\n    pass\n",
+			wantName: "parse_config",
+			wantExt:  ".py",
+			wantLang: "python",
+			wantOk:   true,
+		},
+		{
+			name:     "shebang selects python parser",
+			content:  "#!/usr/bin/env python\ndef main():\n    pass\n",
+			wantName: "main",
+			wantExt:  ".py",
+			wantLang: "python",
+			wantOk:   true,
+		},
+		{
+			name:     "override forces parser even when generic loop would try a different one first",
+			content:  "// dggit:lang=shell\nmain() {\n  echo hi\n}\n",
+			wantName: "main",
+			wantExt:  ".sh",
+			wantLang: "shell",
+			wantOk:   true,
+		},
+		{
+			name:    "no parser recognizes plain prose",
+			content: "just some notes, not code\n",
+			wantOk:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, ext, lang, ok := Default().Derive(tc.content)
+			if ok != tc.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOk)
+			}
+			if !tc.wantOk {
+				return
+			}
+			if name != tc.wantName {
+				t.Errorf("name = %q, want %q", name, tc.wantName)
+			}
+			if ext != tc.wantExt {
+				t.Errorf("ext = %q, want %q", ext, tc.wantExt)
+			}
+			if lang != tc.wantLang {
+				t.Errorf("lang = %q, want %q", lang, tc.wantLang)
+			}
+		})
+	}
+}
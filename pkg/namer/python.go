@@ -0,0 +1,25 @@
+package namer
+
+import "regexp"
+
+var (
+	pyDefRe   = regexp.MustCompile(`^def\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+	pyClassRe = regexp.MustCompile(`^class\s+([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// PythonParser recognizes "def name(" and "class Name" declarations.
+type PythonParser struct{}
+
+func (PythonParser) Lang() string      { return "python" }
+func (PythonParser) Extension() string { return ".py" }
+
+func (PythonParser) Name(content string) (string, bool) {
+	line := firstCodeLine(content)
+	if m := pyDefRe.FindStringSubmatch(line); m != nil {
+		return m[1], true
+	}
+	if m := pyClassRe.FindStringSubmatch(line); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
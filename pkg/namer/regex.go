@@ -0,0 +1,26 @@
+package namer
+
+import "regexp"
+
+// RegexParser is a user-configured fallback, built from the
+// "Namer.<lang>.Pattern" / "Namer.<lang>.Extension" keys in dggit.cfg. The
+// pattern's first capture group is used as the filename.
+type RegexParser struct {
+	LangName string
+	Pattern  *regexp.Regexp
+	Ext      string
+}
+
+func (p RegexParser) Lang() string      { return p.LangName }
+func (p RegexParser) Extension() string { return p.Ext }
+
+func (p RegexParser) Name(content string) (string, bool) {
+	if p.Pattern == nil {
+		return "", false
+	}
+	m := p.Pattern.FindStringSubmatch(firstCodeLine(content))
+	if len(m) < 2 {
+		return "", false
+	}
+	return m[1], true
+}
@@ -0,0 +1,20 @@
+package namer
+
+import "regexp"
+
+// shFuncRe matches a POSIX shell function definition: "name() {".
+var shFuncRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*\(\)\s*\{`)
+
+// ShellParser recognizes shell function definitions.
+type ShellParser struct{}
+
+func (ShellParser) Lang() string      { return "shell" }
+func (ShellParser) Extension() string { return ".sh" }
+
+func (ShellParser) Name(content string) (string, bool) {
+	m := shFuncRe.FindStringSubmatch(firstCodeLine(content))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
@@ -0,0 +1,44 @@
+package reviewui
+
+import (
+	"regexp"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var keywordStyle = lipgloss.NewStyle().Bold(true)
+
+// identifierRe tokenizes a line into identifier-like runs so keywords are
+// matched whole, not as substrings of other identifiers (e.g. "for" inside
+// "before", "if" inside "notify").
+var identifierRe = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+
+// keywordsByLang is a small fingerprint per language, just enough to make
+// the preview panel readable; it isn't a real tokenizer.
+var keywordsByLang = map[string][]string{
+	"go":         {"func", "package", "import", "return", "if", "else", "for", "range", "var", "const", "type", "struct"},
+	"python":     {"def", "class", "import", "return", "if", "elif", "else", "for", "while", "self"},
+	"javascript": {"function", "const", "let", "var", "class", "return", "if", "else", "for", "while"},
+	"shell":      {"if", "then", "else", "fi", "for", "do", "done", "function"},
+	"c":          {"int", "char", "void", "static", "return", "if", "else", "for", "while", "struct"},
+}
+
+// highlightLine bolds recognized keywords for lang. Unknown languages are
+// returned unchanged rather than guessed at.
+func highlightLine(line, lang string) string {
+	words := keywordsByLang[lang]
+	if len(words) == 0 {
+		return line
+	}
+	keywords := make(map[string]bool, len(words))
+	for _, w := range words {
+		keywords[w] = true
+	}
+
+	return identifierRe.ReplaceAllStringFunc(line, func(tok string) string {
+		if keywords[tok] {
+			return keywordStyle.Render(tok)
+		}
+		return tok
+	})
+}
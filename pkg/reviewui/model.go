@@ -0,0 +1,192 @@
+package reviewui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sqweek/dialog"
+)
+
+type focusZone int
+
+const (
+	zoneFilename focusZone = iota
+	zoneFolder
+	zoneCommitToggle
+	zonePushToggle
+	zoneButtons
+
+	zoneCount = zoneButtons + 1
+)
+
+var buttonLabels = []string{"Save", "Save & Commit", "Save & Commit & Push", "Cancel"}
+
+type model struct {
+	filename  string
+	folder    string
+	preview   []string
+	lang      string
+	focus     focusZone
+	buttonIdx int
+	commit    bool
+	push      bool
+	result    Result
+}
+
+func newModel(in Input) model {
+	lines := strings.Split(in.Content, "\n")
+	if len(lines) > 40 {
+		lines = lines[:40]
+	}
+	for i, l := range lines {
+		lines[i] = highlightLine(l, in.Lang)
+	}
+
+	return model{
+		filename: in.Filename,
+		folder:   in.Folder,
+		preview:  lines,
+		lang:     in.Lang,
+		commit:   in.CommitDefault,
+		push:     in.PushDefault,
+		result:   Result{Action: ActionCancel},
+	}
+}
+
+func (m model) Init() tea.Cmd { return nil }
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "esc", "ctrl+c":
+		m.result = Result{Action: ActionCancel}
+		return m, tea.Quit
+	case "tab":
+		m.focus = (m.focus + 1) % zoneCount
+		return m, nil
+	case "shift+tab":
+		m.focus = (m.focus - 1 + zoneCount) % zoneCount
+		return m, nil
+	}
+
+	switch m.focus {
+	case zoneFilename:
+		m.filename = editField(m.filename, keyMsg)
+	case zoneFolder:
+		if keyMsg.String() == "ctrl+o" {
+			if dir, err := dialog.Directory().Title("Select target folder").SetStartDir(m.folder).Browse(); err == nil && dir != "" {
+				m.folder = dir
+			}
+			return m, nil
+		}
+		m.folder = editField(m.folder, keyMsg)
+	case zoneCommitToggle:
+		if keyMsg.String() == " " {
+			m.commit = !m.commit
+		}
+	case zonePushToggle:
+		if keyMsg.String() == " " {
+			m.push = !m.push
+		}
+	case zoneButtons:
+		switch keyMsg.String() {
+		case "left":
+			m.buttonIdx = (m.buttonIdx - 1 + len(buttonLabels)) % len(buttonLabels)
+		case "right":
+			m.buttonIdx = (m.buttonIdx + 1) % len(buttonLabels)
+		case "enter":
+			m.result = m.buttonResult()
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// buttonResult resolves the confirmed action. "Save" respects whatever the
+// commit/push toggles are currently set to; the two explicit buttons are
+// shortcuts that commit/push regardless of the toggle state.
+func (m model) buttonResult() Result {
+	switch buttonLabels[m.buttonIdx] {
+	case "Save":
+		action := ActionSave
+		if m.commit {
+			action = ActionSaveCommit
+		}
+		if m.commit && m.push {
+			action = ActionSaveCommitPush
+		}
+		return Result{Action: action, Filename: m.filename, Folder: m.folder}
+	case "Save & Commit":
+		return Result{Action: ActionSaveCommit, Filename: m.filename, Folder: m.folder}
+	case "Save & Commit & Push":
+		return Result{Action: ActionSaveCommitPush, Filename: m.filename, Folder: m.folder}
+	default:
+		return Result{Action: ActionCancel}
+	}
+}
+
+func editField(value string, keyMsg tea.KeyMsg) string {
+	switch keyMsg.Type {
+	case tea.KeyBackspace:
+		if len(value) > 0 {
+			value = value[:len(value)-1]
+		}
+	case tea.KeyRunes:
+		value += string(keyMsg.Runes)
+	}
+	return value
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "dgGit Review  (lang: %s)\n\n", orDash(m.lang))
+	fmt.Fprintf(&b, "%s Filename: %s\n", marker(m.focus == zoneFilename), m.filename)
+	fmt.Fprintf(&b, "%s Folder:   %s  (Ctrl+O to browse)\n\n", marker(m.focus == zoneFolder), m.folder)
+
+	b.WriteString("Preview:\n")
+	for _, line := range m.preview {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%s [%s] git commit\n", marker(m.focus == zoneCommitToggle), checked(m.commit))
+	fmt.Fprintf(&b, "%s [%s] push\n\n", marker(m.focus == zonePushToggle), checked(m.push))
+
+	for i, label := range buttonLabels {
+		if m.focus == zoneButtons && i == m.buttonIdx {
+			fmt.Fprintf(&b, "[ %s ]  ", label)
+		} else {
+			fmt.Fprintf(&b, "  %s    ", label)
+		}
+	}
+	b.WriteString("\n\nTab: next field   Space: toggle   Enter: confirm   Esc: cancel\n")
+
+	return b.String()
+}
+
+func marker(active bool) string {
+	if active {
+		return ">"
+	}
+	return " "
+}
+
+func checked(b bool) string {
+	if b {
+		return "x"
+	}
+	return " "
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,46 @@
+// Package reviewui implements dgGit's interactive review dialog: a small
+// bubbletea TUI for confirming or editing the filename, target folder and
+// git options before a clipboard save actually happens. It's structured so
+// other confirmation flows (e.g. a future .gitignore editor) can reuse the
+// same model/panel pattern rather than being specific to the save flow.
+package reviewui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Action identifies which button the user picked to close the dialog.
+type Action int
+
+const (
+	ActionCancel Action = iota
+	ActionSave
+	ActionSaveCommit
+	ActionSaveCommitPush
+)
+
+// Input seeds the dialog with dgGit's best guess before the user edits it.
+type Input struct {
+	Filename      string
+	Folder        string
+	Content       string
+	Lang          string // detected namer.Parser.Lang(), or "" if none matched
+	CommitDefault bool   // initial state of the "git commit" toggle
+	PushDefault   bool   // initial state of the "push" toggle
+}
+
+// Result is what Run returns once the user picks a button, or cancels.
+type Result struct {
+	Action   Action
+	Filename string
+	Folder   string
+}
+
+// Run shows the review dialog and blocks until the user picks a button or
+// cancels.
+func Run(in Input) (Result, error) {
+	p := tea.NewProgram(newModel(in))
+	finalModel, err := p.Run()
+	if err != nil {
+		return Result{Action: ActionCancel}, err
+	}
+	return finalModel.(model).result, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/supportproxy/dgGit/pkg/gitops"
+	"github.com/supportproxy/dgGit/pkg/logging"
+)
+
+// runInstallCommand registers dgGit's right-click menu entries, mirroring
+// git-lfs's "git lfs install". By default it targets HKCU (--local);
+// --system targets HKLM and requires an elevated process, like git-lfs's
+// euid check. --force overwrites the command value even if it's already
+// set.
+func runInstallCommand(args []string) {
+	scope, force := parseInstallFlags(args)
+
+	if scope == registryScopeSystem && !isElevated() {
+		fmt.Fprintln(os.Stderr, "dgGit install --system requires an elevated (Administrator) process.")
+		os.Exit(1)
+	}
+
+	if err := installRegistry(scope, force); err != nil {
+		logging.L().WithError(err).Error("install command failed")
+		fmt.Fprintf(os.Stderr, "install failed: %s\n", err)
+		os.Exit(1)
+	}
+	logging.L().Info("install command succeeded")
+	fmt.Println("dgGit installed.")
+}
+
+// runUninstallCommand removes every registry entry runInstallCommand can
+// create, from both scopes, plus the desktop shortcut.
+func runUninstallCommand(args []string) {
+	if err := uninstallRegistry(); err != nil {
+		logging.L().WithError(err).Error("uninstall command failed")
+		fmt.Fprintf(os.Stderr, "uninstall failed: %s\n", err)
+		os.Exit(1)
+	}
+	removeDesktopShortcut()
+	logging.L().Info("uninstall command succeeded")
+	fmt.Println("dgGit uninstalled.")
+}
+
+// runSetupCommand re-runs the first-run preference wizard, regardless of
+// whether a config file already exists.
+func runSetupCommand() {
+	runSetupWizard(configPath())
+}
+
+// runIgnoreCommand is wired to the "dgGit (Ignore)" right-click entry: it
+// takes the clicked file or folder and appends it to the .gitignore in its
+// containing directory, creating that .gitignore if needed.
+func runIgnoreCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: dggit ignore <path>")
+		os.Exit(1)
+	}
+
+	target := strings.TrimSpace(args[0])
+	dir := filepath.Dir(target)
+	pattern := filepath.Base(target)
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		pattern += "/" // ignore the whole folder, not just a same-named file
+	}
+
+	if err := gitops.AppendIgnore(dir, pattern); err != nil {
+		logging.L().WithError(err).Error("ignore command failed")
+		fmt.Fprintf(os.Stderr, "ignore failed: %s\n", err)
+		os.Exit(1)
+	}
+	logging.L().WithField("dir", dir).WithField("pattern", pattern).Info("ignore command succeeded")
+	fmt.Printf("Added %q to %s\n", pattern, filepath.Join(dir, ".gitignore"))
+}
+
+func parseInstallFlags(args []string) (scope registryScope, force bool) {
+	scope = registryScopeLocal
+	for _, a := range args {
+		switch a {
+		case "--system":
+			scope = registryScopeSystem
+		case "--local":
+			scope = registryScopeLocal
+		case "--force":
+			force = true
+		}
+	}
+	return scope, force
+}
+
+// isElevated reports whether the current process token has administrator
+// privileges, mirroring the euid == 0 check git-lfs uses before touching
+// machine-wide state.
+func isElevated() bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
+
+func removeDesktopShortcut() {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	_ = os.Remove(filepath.Join(home, "Desktop", AppName+".lnk"))
+}